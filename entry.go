@@ -0,0 +1,31 @@
+package log
+
+import (
+	"context"
+	"time"
+)
+
+// Entry is the fully decoded record passed to a Hook when a message is
+// logged. It mirrors what sourced() and With(...) attach to the underlying
+// logrus entry, translated into this package's own types so hooks never
+// need to import logrus directly.
+type Entry struct {
+	// Time is when the entry was logged.
+	Time time.Time
+	// Level is the severity the entry was logged at.
+	Level Level
+	// Message is the formatted log message.
+	Message string
+	// Source is the "file:line" sourced() recorded for the entry.
+	Source string
+	// SourceFunc is the calling function sourced() recorded for the entry.
+	SourceFunc string
+	// Error is the error attached via WithError, if any.
+	Error error
+	// Context is the context.Context attached via WithContext, if any, so
+	// hooks can pull request-scoped values (trace IDs, deadlines, ...) out
+	// of it.
+	Context context.Context
+	// Fields holds any remaining fields attached via With.
+	Fields map[string]interface{}
+}
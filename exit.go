@@ -0,0 +1,89 @@
+package log
+
+import (
+	"os"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// exitHandlerTimeout bounds how long a single registered exit handler may
+// run before runExitHandlers gives up on it and moves on to the next one,
+// so a hung handler can't wedge process shutdown.
+const exitHandlerTimeout = 10 * time.Second
+
+var (
+	exitHandlersMu sync.Mutex
+	exitHandlers   []func()
+)
+
+// RegisterExitHandler appends a handler to be run, in LIFO order, before
+// the process terminates via Fatal-level logging. This is the place to
+// flush buffers, close spans, or otherwise let async hooks (Sentry,
+// syslog, ...) ship their final entry before the process disappears.
+//
+// PanicLevel logging still calls panic directly and does not run exit
+// handlers, so recover() continues to work as expected.
+func RegisterExitHandler(handler func()) {
+	exitHandlersMu.Lock()
+	defer exitHandlersMu.Unlock()
+	exitHandlers = append(exitHandlers, handler)
+}
+
+// DeregisterExitHandler removes the most recently registered instance of
+// handler, if any. Handlers are compared by their code pointer
+// (reflect.Value.Pointer), so pass the same func value given to
+// RegisterExitHandler; closures made from the same function literal
+// compare equal.
+func DeregisterExitHandler(handler func()) {
+	exitHandlersMu.Lock()
+	defer exitHandlersMu.Unlock()
+	target := reflect.ValueOf(handler).Pointer()
+	for i := len(exitHandlers) - 1; i >= 0; i-- {
+		if reflect.ValueOf(exitHandlers[i]).Pointer() == target {
+			exitHandlers = append(exitHandlers[:i], exitHandlers[i+1:]...)
+			return
+		}
+	}
+}
+
+// runExitHandlers runs every registered exit handler in LIFO order,
+// bounding each to exitHandlerTimeout so a hung handler can't wedge
+// shutdown. It snapshots the registry under lock before running any
+// handler, so a handler that calls RegisterExitHandler/
+// DeregisterExitHandler doesn't deadlock or race with this loop.
+func runExitHandlers() {
+	exitHandlersMu.Lock()
+	handlers := make([]func(), len(exitHandlers))
+	copy(handlers, exitHandlers)
+	exitHandlersMu.Unlock()
+
+	for i := len(handlers) - 1; i >= 0; i-- {
+		runExitHandler(handlers[i])
+	}
+}
+
+func runExitHandler(handler func()) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		handler()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(exitHandlerTimeout):
+	}
+}
+
+func init() {
+	origLogger.ExitFunc = exitAfterHandlers
+}
+
+// exitAfterHandlers runs every registered exit handler and then terminates
+// the process with code. All Fatal paths route through here rather than
+// calling os.Exit directly.
+func exitAfterHandlers(code int) {
+	runExitHandlers()
+	os.Exit(code)
+}
@@ -0,0 +1,271 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/mattn/go-isatty"
+)
+
+// Formatter renders a completed Entry into the bytes written to a
+// logger's output.
+type Formatter interface {
+	Format(Entry) ([]byte, error)
+}
+
+// init makes TextFormatter the base logger's default, in place of
+// logrus's own default formatter. Every Formatter this package ships
+// builds its output from Entry, which never exposes the hidden
+// contextFieldKey field WithContext stashes a context.Context under, so
+// this keeps a raw context.Context from being printed even if the caller
+// never calls SetFormatter.
+func init() {
+	origLogger.Formatter = &formatterAdapter{NewTextFormatter()}
+}
+
+// FieldMap lets a Formatter's default field names (msg, level, time, ...)
+// be overridden, for callers whose log aggregator expects different keys.
+type FieldMap map[string]string
+
+func (f FieldMap) resolve(key string) string {
+	if renamed, ok := f[key]; ok {
+		return renamed
+	}
+	return key
+}
+
+// Default field keys, overridable via FieldMap.
+const (
+	FieldKeyMsg        = "msg"
+	FieldKeyLevel      = "level"
+	FieldKeyTime       = "time"
+	FieldKeySource     = "source"
+	FieldKeySourceFunc = "source_func"
+	FieldKeyError      = "error"
+)
+
+// formatterAdapter lets a Formatter be registered directly as a
+// logrus.Formatter, since logrus formats its own logrus.Entry rather than
+// ours.
+type formatterAdapter struct {
+	formatter Formatter
+}
+
+func (a *formatterAdapter) Format(e *logrus.Entry) ([]byte, error) {
+	return a.formatter.Format(newEntry(e))
+}
+
+// JSONFormatter formats entries as one JSON object per line.
+type JSONFormatter struct {
+	// TimestampFormat sets the format used for the time field, defaults to
+	// time.RFC3339.
+	TimestampFormat string
+	// FieldMap renames the default field keys.
+	FieldMap FieldMap
+	// PrettyPrint indents the JSON output for readability.
+	PrettyPrint bool
+}
+
+// NewJSONFormatter returns a JSONFormatter with RFC3339 timestamps and no
+// field renaming or pretty-printing.
+func NewJSONFormatter() *JSONFormatter {
+	return &JSONFormatter{TimestampFormat: time.RFC3339}
+}
+
+// Format implements Formatter.
+func (f *JSONFormatter) Format(entry Entry) ([]byte, error) {
+	data := make(map[string]interface{}, len(entry.Fields)+6)
+	for k, v := range entry.Fields {
+		data[k] = v
+	}
+
+	timestampFormat := f.TimestampFormat
+	if timestampFormat == "" {
+		timestampFormat = time.RFC3339
+	}
+
+	data[f.FieldMap.resolve(FieldKeyTime)] = entry.Time.Format(timestampFormat)
+	data[f.FieldMap.resolve(FieldKeyMsg)] = entry.Message
+	data[f.FieldMap.resolve(FieldKeyLevel)] = entry.Level.String()
+	if entry.Source != "" {
+		data[f.FieldMap.resolve(FieldKeySource)] = entry.Source
+	}
+	if entry.SourceFunc != "" {
+		data[f.FieldMap.resolve(FieldKeySourceFunc)] = entry.SourceFunc
+	}
+	if entry.Error != nil {
+		data[f.FieldMap.resolve(FieldKeyError)] = entry.Error.Error()
+	}
+
+	var (
+		b   []byte
+		err error
+	)
+	if f.PrettyPrint {
+		b, err = json.MarshalIndent(data, "", "  ")
+	} else {
+		b, err = json.Marshal(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("json formatter: %s", err)
+	}
+	return append(b, '\n'), nil
+}
+
+// TextFormatter formats entries as human-readable "key=value" lines,
+// colorized when writing to a TTY.
+type TextFormatter struct {
+	// ForceColors forces colored output even when not writing to a TTY.
+	ForceColors bool
+	// DisableColors forces plain output even when writing to a TTY.
+	DisableColors bool
+	// TimestampFormat sets the format used for the time field, defaults to
+	// time.RFC3339.
+	TimestampFormat string
+	// QuoteEmptyFields, when set, renders empty string values as `""`
+	// instead of omitting the quotes entirely.
+	QuoteEmptyFields bool
+
+	isTerminal bool
+}
+
+// NewTextFormatter returns a TextFormatter with RFC3339 timestamps. Colors
+// are auto-detected against os.Stderr, the logger's default output:
+// disabled on Windows and when os.Stderr is not a TTY, enabled otherwise.
+//
+// Format only ever sees an Entry, not the logger's configured output, so
+// this detection can't follow a later SetOut on its own; logger.SetOut
+// re-detects against the new output for any TextFormatter installed via
+// SetFormatter. SetFormatter itself does not re-detect, so a TextFormatter
+// installed after SetOut keeps the os.Stderr result until SetOut is
+// called again.
+func NewTextFormatter() *TextFormatter {
+	return &TextFormatter{
+		TimestampFormat: time.RFC3339,
+		isTerminal:      isTerminalWriter(os.Stderr),
+	}
+}
+
+func isTerminalWriter(w io.Writer) bool {
+	if runtime.GOOS == "windows" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// Format implements Formatter.
+func (f *TextFormatter) Format(entry Entry) ([]byte, error) {
+	var b bytes.Buffer
+
+	timestampFormat := f.TimestampFormat
+	if timestampFormat == "" {
+		timestampFormat = time.RFC3339
+	}
+
+	if f.ForceColors || (f.isTerminal && !f.DisableColors) {
+		f.printColored(&b, entry, timestampFormat)
+	} else {
+		f.appendKeyValue(&b, FieldKeyTime, entry.Time.Format(timestampFormat))
+		f.appendKeyValue(&b, FieldKeyLevel, entry.Level.String())
+		f.appendKeyValue(&b, FieldKeyMsg, entry.Message)
+		if entry.Source != "" {
+			f.appendKeyValue(&b, FieldKeySource, entry.Source)
+		}
+		if entry.SourceFunc != "" {
+			f.appendKeyValue(&b, FieldKeySourceFunc, entry.SourceFunc)
+		}
+		if entry.Error != nil {
+			f.appendKeyValue(&b, FieldKeyError, entry.Error.Error())
+		}
+		for _, k := range sortedKeys(entry.Fields) {
+			f.appendKeyValue(&b, k, entry.Fields[k])
+		}
+	}
+
+	b.WriteByte('\n')
+	return b.Bytes(), nil
+}
+
+func (f *TextFormatter) printColored(b *bytes.Buffer, entry Entry, timestampFormat string) {
+	fmt.Fprintf(b, "\x1b[%dm%-7s\x1b[0m[%s] %s ", colorForLevel(entry.Level), entry.Level.String(), entry.Time.Format(timestampFormat), entry.Message)
+	if entry.Source != "" {
+		fmt.Fprintf(b, "source=%s ", entry.Source)
+	}
+	if entry.SourceFunc != "" {
+		fmt.Fprintf(b, "source_func=%s ", entry.SourceFunc)
+	}
+	if entry.Error != nil {
+		fmt.Fprintf(b, "error=%q ", entry.Error.Error())
+	}
+	for _, k := range sortedKeys(entry.Fields) {
+		fmt.Fprintf(b, "%s=%v ", k, entry.Fields[k])
+	}
+}
+
+func colorForLevel(level Level) int {
+	switch level {
+	case DebugLevel:
+		return 36 // cyan
+	case WarnLevel:
+		return 33 // yellow
+	case ErrorLevel, FatalLevel, PanicLevel:
+		return 31 // red
+	default:
+		return 34 // blue, info
+	}
+}
+
+func sortedKeys(fields map[string]interface{}) []string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (f *TextFormatter) appendKeyValue(b *bytes.Buffer, key string, value interface{}) {
+	if b.Len() > 0 {
+		b.WriteByte(' ')
+	}
+	b.WriteString(key)
+	b.WriteByte('=')
+	f.appendValue(b, value)
+}
+
+func (f *TextFormatter) appendValue(b *bytes.Buffer, value interface{}) {
+	stringVal, ok := value.(string)
+	if !ok {
+		stringVal = fmt.Sprint(value)
+	}
+
+	if f.needsQuoting(stringVal) {
+		fmt.Fprintf(b, "%q", stringVal)
+	} else {
+		b.WriteString(stringVal)
+	}
+}
+
+func (f *TextFormatter) needsQuoting(text string) bool {
+	if text == "" {
+		return f.QuoteEmptyFields
+	}
+	for _, ch := range text {
+		if !((ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || (ch >= '0' && ch <= '9') ||
+			ch == '-' || ch == '.' || ch == '_' || ch == '/' || ch == '@' || ch == '^' || ch == '+') {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+package log
+
+import (
+	"context"
+
+	"github.com/Sirupsen/logrus"
+)
+
+// Hook allows fanning log entries out to external sinks, such as an error
+// tracking service or syslog. A Hook is fired for every entry logged at one
+// of the levels it returns from Levels.
+type Hook interface {
+	// Levels returns the levels this hook wants to be fired for.
+	Levels() []Level
+	// Fire is called with the completed Entry whenever it is logged at one
+	// of Levels. A returned error is logged but does not stop logging.
+	Fire(entry Entry) error
+}
+
+// LevelHooks is a map of levels to the Hooks registered for them. It
+// mirrors the logrus.LevelHooks type so the full set of hooks can be
+// inspected or replaced wholesale.
+type LevelHooks map[Level][]Hook
+
+// Add registers hook for every level it reports interest in.
+func (hooks LevelHooks) Add(hook Hook) {
+	for _, level := range hook.Levels() {
+		hooks[level] = append(hooks[level], hook)
+	}
+}
+
+// Fire invokes every hook registered for level, returning the first error
+// encountered, if any.
+func (hooks LevelHooks) Fire(level Level, entry Entry) error {
+	for _, hook := range hooks[level] {
+		if err := hook.Fire(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hookAdapter lets a Hook be registered directly on a logrus.Logger, since
+// logrus fires its own logrus.Hook interface rather than ours.
+type hookAdapter struct {
+	hook Hook
+}
+
+func (a *hookAdapter) Levels() []logrus.Level {
+	levels := a.hook.Levels()
+	out := make([]logrus.Level, len(levels))
+	for i, level := range levels {
+		out[i] = logrus.Level(level)
+	}
+	return out
+}
+
+func (a *hookAdapter) Fire(e *logrus.Entry) error {
+	return a.hook.Fire(newEntry(e))
+}
+
+// newEntry translates a logrus.Entry into an Entry, pulling the
+// source/source_func/error fields sourced() and WithError attached back
+// out as first-class fields rather than leaving them buried in Fields.
+func newEntry(e *logrus.Entry) Entry {
+	fields := make(map[string]interface{}, len(e.Data))
+	entry := Entry{
+		Time:    e.Time,
+		Level:   Level(e.Level),
+		Message: e.Message,
+		Fields:  fields,
+	}
+	for k, v := range e.Data {
+		switch k {
+		case "source":
+			entry.Source, _ = v.(string)
+		case "source_func":
+			entry.SourceFunc, _ = v.(string)
+		case logrus.ErrorKey:
+			entry.Error, _ = v.(error)
+		case contextFieldKey:
+			entry.Context, _ = v.(context.Context)
+		default:
+			fields[k] = v
+		}
+	}
+	return entry
+}
+
+// AddHook registers hook on the logger, so it fires for every future entry
+// logged at one of its levels.
+func (l logger) AddHook(hook Hook) {
+	l.entry.Logger.Hooks.Add(&hookAdapter{hook})
+}
+
+// Hooks returns the hooks currently registered on the logger.
+func (l logger) Hooks() LevelHooks {
+	hooks := make(LevelHooks)
+	for level, registered := range l.entry.Logger.Hooks {
+		for _, h := range registered {
+			if adapter, ok := h.(*hookAdapter); ok {
+				hooks[Level(level)] = append(hooks[Level(level)], adapter.hook)
+			}
+		}
+	}
+	return hooks
+}
+
+// SetHooks replaces the logger's entire set of hooks with hooks. It copies
+// hooks' map structure directly rather than going through Add, since
+// hooks already has each hook listed once per level it covers (that's
+// what Hooks and LevelHooks.Add do) - re-running that expansion through
+// Add here would register every hook once per level per occurrence,
+// multiplying how many times it fires.
+func (l logger) SetHooks(hooks LevelHooks) {
+	registered := make(logrus.LevelHooks)
+	for level, levelHooks := range hooks {
+		for _, hook := range levelHooks {
+			registered[logrus.Level(level)] = append(registered[logrus.Level(level)], &hookAdapter{hook})
+		}
+	}
+	l.entry.Logger.Hooks = registered
+}
+
+// AddHook registers hook on the base logger.
+func AddHook(hook Hook) {
+	baseLogger.AddHook(hook)
+}
+
+// Hooks returns the hooks currently registered on the base logger.
+func Hooks() LevelHooks {
+	return baseLogger.Hooks()
+}
+
+// SetHooks replaces the base logger's entire set of hooks with hooks.
+func SetHooks(hooks LevelHooks) {
+	baseLogger.SetHooks(hooks)
+}
@@ -0,0 +1,154 @@
+// Package sentry provides a log.Hook that ships Error/Fatal/Panic entries
+// to Sentry via raven-go.
+package sentry
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	raven "github.com/getsentry/raven-go"
+
+	"github.com/agend07/log"
+)
+
+// defaultTimeout bounds how long Fire waits for Sentry to accept a packet,
+// so an unreachable DSN can't stall the caller.
+const defaultTimeout = 100 * time.Millisecond
+
+var levelSeverities = map[log.Level]raven.Severity{
+	log.PanicLevel: raven.FATAL,
+	log.FatalLevel: raven.FATAL,
+	log.ErrorLevel: raven.ERROR,
+	log.WarnLevel:  raven.WARNING,
+	log.InfoLevel:  raven.INFO,
+	log.DebugLevel: raven.DEBUG,
+}
+
+// Hook ships log entries to Sentry.
+type Hook struct {
+	// Timeout bounds how long Fire waits for Sentry to acknowledge a
+	// packet. Zero means defaultTimeout.
+	Timeout time.Duration
+
+	client *raven.Client
+	levels []log.Level
+}
+
+// New returns a Hook that reports Error/Fatal/Panic entries to the given
+// Sentry DSN.
+func New(dsn string) (*Hook, error) {
+	client, err := raven.New(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return NewWithClient(client), nil
+}
+
+// NewWithClient returns a Hook backed by an already-initialized Sentry
+// client, for callers that need custom tags, release, or environment and
+// so want to build and reuse their own raven.Client.
+func NewWithClient(client *raven.Client) *Hook {
+	return &Hook{
+		client: client,
+		levels: []log.Level{
+			log.PanicLevel,
+			log.FatalLevel,
+			log.ErrorLevel,
+		},
+	}
+}
+
+// Levels returns the levels this hook fires for.
+func (h *Hook) Levels() []log.Level {
+	return h.levels
+}
+
+// Fire sends entry to Sentry, promoting entry.Error to the exception
+// interface and any *http.Request field to Sentry's HTTP interface.
+func (h *Hook) Fire(entry log.Entry) error {
+	packet := raven.NewPacket(entry.Message, h.interfaces(entry)...)
+	packet.Level = levelSeverities[entry.Level]
+	packet.Extra = h.extra(entry)
+
+	_, ch := h.client.Capture(packet, nil)
+
+	timeout := h.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	select {
+	case err := <-ch:
+		return err
+	case <-time.After(timeout):
+		return fmt.Errorf("sentry: timed out waiting for response after %s", timeout)
+	}
+}
+
+func (h *Hook) interfaces(entry log.Entry) []raven.Interface {
+	var interfaces []raven.Interface
+
+	if entry.Error != nil {
+		interfaces = append(interfaces, raven.NewException(entry.Error, sourceStacktrace(entry)))
+	}
+
+	for _, v := range entry.Fields {
+		if req, ok := v.(*http.Request); ok {
+			interfaces = append(interfaces, raven.NewHttp(req))
+			break
+		}
+	}
+
+	return interfaces
+}
+
+// sourceStacktrace builds a single-frame Stacktrace from entry.Source and
+// entry.SourceFunc - the file:line and function sourced() recorded at the
+// log call site - rather than capturing the real stack, which at Fire
+// time would point into logrus's hook-dispatch internals instead of
+// wherever WithError was actually called. Returns nil if sourced() didn't
+// record anything, so the exception carries no misleading frame.
+func sourceStacktrace(entry log.Entry) *raven.Stacktrace {
+	if entry.Source == "" {
+		return nil
+	}
+
+	file, lineno := entry.Source, 0
+	if idx := strings.LastIndex(entry.Source, ":"); idx >= 0 {
+		file = entry.Source[:idx]
+		if n, err := strconv.Atoi(entry.Source[idx+1:]); err == nil {
+			lineno = n
+		}
+	}
+
+	return &raven.Stacktrace{
+		Frames: []*raven.StacktraceFrame{
+			{
+				Filename: file,
+				Function: entry.SourceFunc,
+				Lineno:   lineno,
+				InApp:    true,
+			},
+		},
+	}
+}
+
+func (h *Hook) extra(entry log.Entry) map[string]interface{} {
+	extra := make(map[string]interface{}, len(entry.Fields)+2)
+	for k, v := range entry.Fields {
+		if _, ok := v.(*http.Request); ok {
+			continue
+		}
+		extra[k] = v
+	}
+	if entry.Source != "" {
+		extra["source"] = entry.Source
+	}
+	if entry.SourceFunc != "" {
+		extra["source_func"] = entry.SourceFunc
+	}
+	return extra
+}
@@ -0,0 +1,66 @@
+//go:build !windows && !nacl && !plan9
+// +build !windows,!nacl,!plan9
+
+// Package syslog provides a log.Hook that writes entries to a local or
+// remote syslog daemon.
+package syslog
+
+import (
+	"fmt"
+	"log/syslog"
+
+	"github.com/agend07/log"
+)
+
+// Hook ships log entries to syslog.
+type Hook struct {
+	Writer        *syslog.Writer
+	SyslogNetwork string
+	SyslogRaddr   string
+}
+
+// New dials the syslog daemon at raddr over network ("", "" dials the
+// local syslog) and returns a Hook that writes to it.
+func New(network, raddr string, priority syslog.Priority, tag string) (*Hook, error) {
+	w, err := syslog.Dial(network, raddr, priority, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &Hook{
+		Writer:        w,
+		SyslogNetwork: network,
+		SyslogRaddr:   raddr,
+	}, nil
+}
+
+// Levels returns all levels; syslog has a severity for each of them.
+func (h *Hook) Levels() []log.Level {
+	return []log.Level{
+		log.PanicLevel,
+		log.FatalLevel,
+		log.ErrorLevel,
+		log.WarnLevel,
+		log.InfoLevel,
+		log.DebugLevel,
+	}
+}
+
+// Fire writes entry to syslog at the severity matching entry.Level.
+func (h *Hook) Fire(entry log.Entry) error {
+	switch entry.Level {
+	case log.PanicLevel:
+		return h.Writer.Crit(entry.Message)
+	case log.FatalLevel:
+		return h.Writer.Crit(entry.Message)
+	case log.ErrorLevel:
+		return h.Writer.Err(entry.Message)
+	case log.WarnLevel:
+		return h.Writer.Warning(entry.Message)
+	case log.InfoLevel:
+		return h.Writer.Info(entry.Message)
+	case log.DebugLevel:
+		return h.Writer.Debug(entry.Message)
+	default:
+		return fmt.Errorf("syslog: unknown level %v", entry.Level)
+	}
+}
@@ -1,6 +1,7 @@
 package log
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"runtime"
@@ -31,40 +32,92 @@ const (
 	DebugLevel
 )
 
+// String returns the name of level, as used by the formatters.
+func (level Level) String() string {
+	switch level {
+	case PanicLevel:
+		return "panic"
+	case FatalLevel:
+		return "fatal"
+	case ErrorLevel:
+		return "error"
+	case WarnLevel:
+		return "warning"
+	case InfoLevel:
+		return "info"
+	case DebugLevel:
+		return "debug"
+	default:
+		return "unknown"
+	}
+}
+
 // Logger is an interface that describes logging.
 type Logger interface {
 	SetLevel(level Level)
 	SetOut(out io.Writer)
+	SetFormatter(formatter Formatter)
 
 	Debug(...interface{})
 	Debugln(...interface{})
+	Debugf(format string, args ...interface{})
 
 	Info(...interface{})
 	Infoln(...interface{})
+	Infof(format string, args ...interface{})
 
 	Warn(...interface{})
 	Warnln(...interface{})
+	Warnf(format string, args ...interface{})
 
 	Error(...interface{})
 	Errorln(...interface{})
+	Errorf(format string, args ...interface{})
 
 	Fatal(...interface{})
 	Fatalln(...interface{})
+	Fatalf(format string, args ...interface{})
 
 	With(key string, value interface{}) Logger
 	WithError(err error) Logger
+	WithFields(fields map[string]interface{}) Logger
+	WithContext(ctx context.Context) Logger
+
+	AddHook(hook Hook)
+	Hooks() LevelHooks
+	SetHooks(hooks LevelHooks)
+
+	Writer() *io.PipeWriter
+	WriterLevel(level Level) *io.PipeWriter
 }
 
+// contextFieldKey is the hidden field WithContext stashes its
+// context.Context under, so it can be pulled back out as Entry.Context
+// without polluting Entry.Fields. It's deliberately namespaced so it
+// doesn't collide with a caller's own "context" field, and is stripped
+// before rendering by every Formatter this package ships (see
+// newEntry and init in formatter.go, which makes one of them the
+// default so a context.Context value is never printed raw).
+const contextFieldKey = "github.com/agend07/log.context"
+
 type logger struct {
 	entry *logrus.Entry
 }
 
 func (l logger) With(key string, value interface{}) Logger {
-	return logger{l.entry.WithField(key, value)}
+	return logger{entry: l.entry.WithField(key, value)}
 }
 
 func (l logger) WithError(err error) Logger {
-	return logger{l.entry.WithError(err)}
+	return logger{entry: l.entry.WithError(err)}
+}
+
+func (l logger) WithFields(fields map[string]interface{}) Logger {
+	return logger{entry: l.entry.WithFields(logrus.Fields(fields))}
+}
+
+func (l logger) WithContext(ctx context.Context) Logger {
+	return logger{entry: l.entry.WithField(contextFieldKey, ctx)}
 }
 
 func (l logger) SetLevel(level Level) {
@@ -73,62 +126,98 @@ func (l logger) SetLevel(level Level) {
 
 func (l logger) SetOut(out io.Writer) {
 	l.entry.Logger.Out = out
+	if adapter, ok := l.entry.Logger.Formatter.(*formatterAdapter); ok {
+		if text, ok := adapter.formatter.(*TextFormatter); ok {
+			text.isTerminal = isTerminalWriter(out)
+		}
+	}
+}
+
+func (l logger) SetFormatter(formatter Formatter) {
+	l.entry.Logger.Formatter = &formatterAdapter{formatter}
 }
 
 // Debug logs a message at level Debug on the standard logger.
 func (l logger) Debug(args ...interface{}) {
-	l.sourced().Debug(args...)
+	l.sourced(2).Debug(args...)
 }
 
 // Debugln logs a message at level Debug on the standard logger.
 func (l logger) Debugln(args ...interface{}) {
-	l.sourced().Debugln(args...)
+	l.sourced(2).Debugln(args...)
+}
+
+// Debugf logs a formatted message at level Debug on the standard logger.
+func (l logger) Debugf(format string, args ...interface{}) {
+	l.sourced(2).Debugf(format, args...)
 }
 
 // Info logs a message at level Info on the standard logger.
 func (l logger) Info(args ...interface{}) {
-	l.sourced().Info(args...)
+	l.sourced(2).Info(args...)
 }
 
 // Infoln logs a message at level Info on the standard logger.
 func (l logger) Infoln(args ...interface{}) {
-	l.sourced().Infoln(args...)
+	l.sourced(2).Infoln(args...)
+}
+
+// Infof logs a formatted message at level Info on the standard logger.
+func (l logger) Infof(format string, args ...interface{}) {
+	l.sourced(2).Infof(format, args...)
 }
 
 // Warn logs a message at level Warn on the standard logger.
 func (l logger) Warn(args ...interface{}) {
-	l.sourced().Warn(args...)
+	l.sourced(2).Warn(args...)
 }
 
 // Warnln logs a message at level Warn on the standard logger.
 func (l logger) Warnln(args ...interface{}) {
-	l.sourced().Warnln(args...)
+	l.sourced(2).Warnln(args...)
+}
+
+// Warnf logs a formatted message at level Warn on the standard logger.
+func (l logger) Warnf(format string, args ...interface{}) {
+	l.sourced(2).Warnf(format, args...)
 }
 
 // Error logs a message at level Error on the standard logger.
 func (l logger) Error(args ...interface{}) {
-	l.sourced().Error(args...)
+	l.sourced(2).Error(args...)
 }
 
 // Errorln logs a message at level Error on the standard logger.
 func (l logger) Errorln(args ...interface{}) {
-	l.sourced().Errorln(args...)
+	l.sourced(2).Errorln(args...)
+}
+
+// Errorf logs a formatted message at level Error on the standard logger.
+func (l logger) Errorf(format string, args ...interface{}) {
+	l.sourced(2).Errorf(format, args...)
 }
 
 // Fatal logs a message at level Fatal on the standard logger.
 func (l logger) Fatal(args ...interface{}) {
-	l.sourced().Fatal(args...)
+	l.sourced(2).Fatal(args...)
 }
 
 // Fatalln logs a message at level Fatal on the standard logger.
 func (l logger) Fatalln(args ...interface{}) {
-	l.sourced().Fatalln(args...)
+	l.sourced(2).Fatalln(args...)
+}
+
+// Fatalf logs a formatted message at level Fatal on the standard logger.
+func (l logger) Fatalf(format string, args ...interface{}) {
+	l.sourced(2).Fatalf(format, args...)
 }
 
-// sourced adds a source field to the logger that contains
-// the file name and line where the logging happened.
-func (l logger) sourced() *logrus.Entry {
-	pc, file, line, ok := runtime.Caller(2)
+// sourced adds a source field to the logger that contains the file name
+// and line where the logging happened. skip is the number of stack frames
+// to ascend, passed straight to runtime.Caller; every logging method here
+// calls it directly and passes 2, the frame of its own caller.
+func (l logger) sourced(skip int) *logrus.Entry {
+	pc, file, line, ok := runtime.Caller(skip)
 	fn := "(unknown)"
 	if !ok {
 		file = "<???>"
@@ -160,6 +249,11 @@ func SetLevel(level Level) {
 	baseLogger.entry.Level = logrus.Level(level)
 }
 
+// SetFormatter sets the Formatter of the base logger.
+func SetFormatter(formatter Formatter) {
+	baseLogger.SetFormatter(formatter)
+}
+
 // With attaches a key,value pair to a logger.
 func With(key string, value interface{}) Logger {
 	return baseLogger.With(key, value)
@@ -167,55 +261,91 @@ func With(key string, value interface{}) Logger {
 
 // WithError returns a Logger that will print an error along with the next message.
 func WithError(err error) Logger {
-	return logger{entry: baseLogger.sourced().WithError(err)}
+	return logger{entry: baseLogger.sourced(2).WithError(err)}
+}
+
+// WithFields attaches many key,value pairs to a logger in one call.
+func WithFields(fields map[string]interface{}) Logger {
+	return baseLogger.WithFields(fields)
+}
+
+// WithContext attaches a context.Context to a logger, so hooks can pull
+// request-scoped values out of it.
+func WithContext(ctx context.Context) Logger {
+	return baseLogger.WithContext(ctx)
 }
 
 // Debug logs a message at level Debug on the standard logger.
 func Debug(args ...interface{}) {
-	baseLogger.sourced().Debug(args...)
+	baseLogger.sourced(2).Debug(args...)
 }
 
 // Debugln logs a message at level Debug on the standard logger.
 func Debugln(args ...interface{}) {
-	baseLogger.sourced().Debugln(args...)
+	baseLogger.sourced(2).Debugln(args...)
+}
+
+// Debugf logs a formatted message at level Debug on the standard logger.
+func Debugf(format string, args ...interface{}) {
+	baseLogger.sourced(2).Debugf(format, args...)
 }
 
 // Info logs a message at level Info on the standard logger.
 func Info(args ...interface{}) {
-	baseLogger.sourced().Info(args...)
+	baseLogger.sourced(2).Info(args...)
 }
 
 // Infoln logs a message at level Info on the standard logger.
 func Infoln(args ...interface{}) {
-	baseLogger.sourced().Infoln(args...)
+	baseLogger.sourced(2).Infoln(args...)
+}
+
+// Infof logs a formatted message at level Info on the standard logger.
+func Infof(format string, args ...interface{}) {
+	baseLogger.sourced(2).Infof(format, args...)
 }
 
 // Warn logs a message at level Warn on the standard logger.
 func Warn(args ...interface{}) {
-	baseLogger.sourced().Warn(args...)
+	baseLogger.sourced(2).Warn(args...)
 }
 
 // Warnln logs a message at level Warn on the standard logger.
 func Warnln(args ...interface{}) {
-	baseLogger.sourced().Warnln(args...)
+	baseLogger.sourced(2).Warnln(args...)
+}
+
+// Warnf logs a formatted message at level Warn on the standard logger.
+func Warnf(format string, args ...interface{}) {
+	baseLogger.sourced(2).Warnf(format, args...)
 }
 
 // Error logs a message at level Error on the standard logger.
 func Error(args ...interface{}) {
-	baseLogger.sourced().Error(args...)
+	baseLogger.sourced(2).Error(args...)
 }
 
 // Errorln logs a message at level Error on the standard logger.
 func Errorln(args ...interface{}) {
-	baseLogger.sourced().Errorln(args...)
+	baseLogger.sourced(2).Errorln(args...)
+}
+
+// Errorf logs a formatted message at level Error on the standard logger.
+func Errorf(format string, args ...interface{}) {
+	baseLogger.sourced(2).Errorf(format, args...)
 }
 
 // Fatal logs a message at level Fatal on the standard logger.
 func Fatal(args ...interface{}) {
-	baseLogger.sourced().Fatal(args...)
+	baseLogger.sourced(2).Fatal(args...)
 }
 
 // Fatalln logs a message at level Fatal on the standard logger.
 func Fatalln(args ...interface{}) {
-	baseLogger.sourced().Fatalln(args...)
+	baseLogger.sourced(2).Fatalln(args...)
+}
+
+// Fatalf logs a formatted message at level Fatal on the standard logger.
+func Fatalf(format string, args ...interface{}) {
+	baseLogger.sourced(2).Fatalf(format, args...)
 }
@@ -0,0 +1,90 @@
+package log
+
+import (
+	"bufio"
+	"io"
+	"runtime"
+)
+
+// defaultWriterBufferSize is the initial size of the buffer writerScanner
+// gives bufio.Scanner. maxWriterBufferSize is the largest a single line
+// may grow to before it's treated as a scan error, well past the 64KB
+// bufio.Scanner otherwise caps out at.
+const (
+	defaultWriterBufferSize = 64 * 1024
+	maxWriterBufferSize     = 1024 * 1024
+)
+
+// Writer returns an io.PipeWriter that logs each line written to it at
+// Info level, preserving any fields already attached to the logger. This
+// lets a stdlib *log.Logger, http.Server.ErrorLog, exec.Cmd.Stderr, or any
+// other API that only accepts an io.Writer feed into structured logging.
+func (l logger) Writer() *io.PipeWriter {
+	return l.WriterLevel(InfoLevel)
+}
+
+// WriterLevel is like Writer but logs each line at level, capped at
+// Error: PanicLevel and FatalLevel would otherwise let a line read from
+// third-party output panic or exit the process from inside the
+// background reader goroutine.
+func (l logger) WriterLevel(level Level) *io.PipeWriter {
+	reader, writer := io.Pipe()
+
+	printFunc := l.printFuncFor(level)
+
+	go writerScanner(reader, printFunc)
+	runtime.SetFinalizer(writer, writerFinalizer)
+
+	return writer
+}
+
+// printFuncFor caps the level a writer logs at to Error. Third-party
+// output (http.Server.ErrorLog, exec.Cmd.Stderr, ...) is read line by
+// line in a background goroutine, so routing PanicLevel/FatalLevel
+// through here would let a single stray line call panic or os.Exit(1)
+// from that goroutine and tear down the whole process out from under the
+// caller.
+func (l logger) printFuncFor(level Level) func(...interface{}) {
+	switch level {
+	case PanicLevel, FatalLevel, ErrorLevel:
+		return l.Error
+	case WarnLevel:
+		return l.Warn
+	case DebugLevel:
+		return l.Debug
+	default:
+		return l.Info
+	}
+}
+
+// writerScanner reads reader line by line, logging each one via
+// printFunc, until the writer side is closed (EOF) or a scan error
+// occurs. It gives the scanner a buffer larger than bufio.Scanner's
+// default so a single long line isn't dropped as an error.
+func writerScanner(reader *io.PipeReader, printFunc func(...interface{})) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, defaultWriterBufferSize), maxWriterBufferSize)
+	for scanner.Scan() {
+		printFunc(scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		Errorf("log: error reading from writer: %s", err)
+	}
+	reader.Close()
+}
+
+func writerFinalizer(writer *io.PipeWriter) {
+	writer.Close()
+}
+
+// Writer returns an io.PipeWriter that logs each line written to it at
+// Info level on the base logger.
+func Writer() *io.PipeWriter {
+	return baseLogger.Writer()
+}
+
+// WriterLevel is like Writer but logs each line at level on the base
+// logger.
+func WriterLevel(level Level) *io.PipeWriter {
+	return baseLogger.WriterLevel(level)
+}